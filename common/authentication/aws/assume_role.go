@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// assumeRole returns a session whose credentials come from assuming opts.AssumeRoleChain (or,
+// if unset, the single opts.AssumeRoleARN) in order, or sess unchanged if neither is configured.
+// When opts.WebIdentityTokenFile is set, the first role in the chain is assumed via STS
+// AssumeRoleWithWebIdentity using that projected token instead of sess's ambient credentials.
+func assumeRole(sess *session.Session, opts Options) (*session.Session, error) {
+	chain := opts.AssumeRoleChain
+	if len(chain) == 0 && opts.AssumeRoleARN != "" {
+		chain = []string{opts.AssumeRoleARN}
+	}
+	if len(chain) == 0 {
+		return sess, nil
+	}
+
+	sessionName := opts.SessionName
+	if sessionName == "" {
+		sessionName = "DaprDefaultSession"
+	}
+
+	var creds *credentials.Credentials
+	if opts.WebIdentityTokenFile != "" {
+		// AssumeRoleWithWebIdentity takes no external ID, so ExternalID only applies to the
+		// AssumeRole hops below.
+		creds = stscreds.NewWebIdentityRoleCredentialsWithOptions(
+			sts.New(sess), chain[0], sessionName, stscreds.FetchTokenPath(opts.WebIdentityTokenFile),
+		)
+		chain = chain[1:]
+	}
+
+	for _, roleARN := range chain {
+		hopSess := sess
+		if creds != nil {
+			hopSess = sess.Copy(&aws.Config{Credentials: creds})
+		}
+		creds = stscreds.NewCredentials(hopSess, roleARN, func(p *stscreds.AssumeRoleProvider) {
+			p.RoleSessionName = sessionName
+			if opts.ExternalID != "" {
+				p.ExternalID = &opts.ExternalID
+			}
+		})
+	}
+
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
+}
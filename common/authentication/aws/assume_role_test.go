@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// mustSession builds a session with no credentials resolved yet. Credential providers in the
+// AWS SDK are lazy - constructing one doesn't make a network call - so these tests can exercise
+// assumeRole's branching without reaching AWS.
+func mustSession(t *testing.T) *session.Session {
+	t.Helper()
+	sess, err := session.NewSession(aws.NewConfig().WithRegion("us-east-1"))
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	return sess
+}
+
+func TestAssumeRoleNoop(t *testing.T) {
+	sess := mustSession(t)
+
+	got, err := assumeRole(sess, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != sess {
+		t.Fatal("expected assumeRole to return the original session unchanged when no role is configured")
+	}
+}
+
+func TestAssumeRoleSingleARN(t *testing.T) {
+	sess := mustSession(t)
+
+	got, err := assumeRole(sess, Options{AssumeRoleARN: "arn:aws:iam::123456789012:role/example"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == sess {
+		t.Fatal("expected assumeRole to return a new session carrying assumed-role credentials")
+	}
+	if got.Config.Credentials == sess.Config.Credentials {
+		t.Fatal("expected the assumed-role session to have its own Credentials provider")
+	}
+}
+
+func TestAssumeRoleChain(t *testing.T) {
+	sess := mustSession(t)
+
+	got, err := assumeRole(sess, Options{
+		AssumeRoleChain: []string{
+			"arn:aws:iam::111111111111:role/first",
+			"arn:aws:iam::222222222222:role/second",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == sess {
+		t.Fatal("expected assumeRole to return a new session for a role chain")
+	}
+}
+
+func TestAssumeRoleChainWithSingleARNSet(t *testing.T) {
+	sess := mustSession(t)
+
+	got, err := assumeRole(sess, Options{
+		AssumeRoleARN:   "arn:aws:iam::333333333333:role/ignored",
+		AssumeRoleChain: []string{"arn:aws:iam::111111111111:role/first"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == sess {
+		t.Fatal("expected assumeRole to return a new session when AssumeRoleChain is set")
+	}
+}
+
+func TestAssumeRoleWebIdentityWithChain(t *testing.T) {
+	sess := mustSession(t)
+
+	got, err := assumeRole(sess, Options{
+		AssumeRoleChain: []string{
+			"arn:aws:iam::111111111111:role/web-identity",
+			"arn:aws:iam::222222222222:role/second",
+		},
+		WebIdentityTokenFile: "/var/run/secrets/token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == sess {
+		t.Fatal("expected assumeRole to return a new session when using a web identity token")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package aws contains the authentication settings shared by Dapr's AWS-backed components.
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	"github.com/dapr/kit/logger"
+)
+
+// PodIdentityAWSEKS resolves credentials via IRSA/EKS Pod Identity through the default AWS
+// credential provider chain, instead of requiring static accessKey/secretKey metadata.
+const PodIdentityAWSEKS = "aws-eks"
+
+// Options contains the authentication settings common to Dapr's AWS components.
+type Options struct {
+	Region       string
+	Endpoint     string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+
+	// AssumeRoleARN and SessionName configure a single STS AssumeRole call. ExternalID is
+	// passed along to every AssumeRole call for cross-account trust relationships that
+	// require it; the AssumeRoleWithWebIdentity hop takes no external ID.
+	AssumeRoleARN string
+	SessionName   string
+	ExternalID    string
+
+	// WebIdentityTokenFile points at a projected service-account token (e.g.
+	// AWS_WEB_IDENTITY_TOKEN_FILE) used to authenticate the first hop of AssumeRoleChain via
+	// STS AssumeRoleWithWebIdentity, instead of the session's ambient credentials.
+	WebIdentityTokenFile string
+
+	// AssumeRoleChain lets operators authenticate through multiple roles in order, e.g.
+	// ["tenant-role", "db-role"]: each entry is assumed using the credentials produced by
+	// the previous one (or by the web identity token for the first entry, when
+	// WebIdentityTokenFile is set). When set, it takes precedence over AssumeRoleARN.
+	AssumeRoleChain []string
+
+	// PodIdentity selects a platform-managed credential source instead of the static
+	// accessKey/secretKey pair above. Only PodIdentityAWSEKS is currently supported: when set,
+	// credentials are resolved through the default AWS credential provider chain, which picks
+	// up IRSA/EKS Pod Identity automatically. It's mutually exclusive with AccessKey/SecretKey;
+	// NewProvider rejects opts that set both, rather than silently letting one take precedence.
+	PodIdentity string
+
+	Logger     logger.Logger
+	Properties map[string]string
+}
+
+// EnvironmentSettings carries the metadata properties relevant to AWS authentication.
+type EnvironmentSettings struct {
+	Metadata map[string]string
+}
+
+// NewEnvironmentSettings returns an EnvironmentSettings populated from component metadata.
+func NewEnvironmentSettings(md map[string]string) (EnvironmentSettings, error) {
+	return EnvironmentSettings{Metadata: md}, nil
+}
+
+// GetConfig returns the base *aws.Config for opts. When opts carries no static credentials,
+// the returned config has no Credentials set, so session.NewSession falls back to the SDK's
+// default credential provider chain (environment, shared config, EC2/ECS/EKS instance
+// metadata, and - for PodIdentityAWSEKS - the projected service account token).
+func GetConfig(opts Options) *aws.Config {
+	cfg := aws.NewConfig()
+	if opts.Region != "" {
+		cfg = cfg.WithRegion(opts.Region)
+	}
+	if opts.Endpoint != "" {
+		cfg = cfg.WithEndpoint(opts.Endpoint)
+	}
+	if opts.AccessKey != "" || opts.SecretKey != "" {
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(opts.AccessKey, opts.SecretKey, opts.SessionToken))
+	}
+	return cfg
+}
+
+// SecretManagerClient wraps the AWS Secrets Manager client used by the secretmanager store.
+type SecretManagerClient struct {
+	Manager *secretsmanager.SecretsManager
+}
+
+// Provider exposes the AWS service clients used by Dapr components.
+type Provider interface {
+	SecretManager() *SecretManagerClient
+	Close() error
+}
+
+type provider struct {
+	secretManager *SecretManagerClient
+}
+
+func (p *provider) SecretManager() *SecretManagerClient { return p.secretManager }
+
+func (p *provider) Close() error { return nil }
+
+// NewProvider creates an AWS Provider, resolving credentials from opts.
+func NewProvider(ctx context.Context, opts Options, cfg *aws.Config) (Provider, error) {
+	if opts.PodIdentity != "" && (opts.AccessKey != "" || opts.SecretKey != "") {
+		return nil, fmt.Errorf("podIdentity %q is mutually exclusive with accessKey/secretKey metadata", opts.PodIdentity)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	sess, err = assumeRole(sess, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider{
+		secretManager: &SecretManagerClient{Manager: secretsmanager.New(sess)},
+	}, nil
+}
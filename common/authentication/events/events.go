@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events defines a small, provider-agnostic event-sink abstraction that
+// authentication-adjacent components use to surface operational occurrences (token
+// refreshes, cache hits/misses, throttling) to operators without requiring debug logging
+// to be enabled on the whole sidecar.
+package events
+
+// Type identifies what kind of event occurred. Each component defines its own set of
+// Type constants relevant to what it can observe.
+type Type string
+
+// AuthEvent describes a single authentication or credential-fetch related occurrence.
+type AuthEvent struct {
+	Type    Type
+	Message string
+	Err     error
+}
+
+// Sink receives AuthEvent notifications.
+type Sink interface {
+	Emit(event AuthEvent)
+}
@@ -17,14 +17,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/dapr/components-contrib/common/authentication/aws"
 	"github.com/dapr/components-contrib/common/authentication/azure"
+	"github.com/dapr/components-contrib/common/authentication/events"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 )
@@ -37,11 +41,43 @@ type PostgresAuthMetadata struct {
 	UseAzureAD            bool          `mapstructure:"useAzureAD"`
 	UseAWSIAM             bool          `mapstructure:"useAWSIAM"`
 	QueryExecMode         string        `mapstructure:"queryExecMode"`
+	PodIdentity           string        `mapstructure:"podIdentity"`
+	SessionInitSql        []string      `mapstructure:"sessionInitSql"`
+	PgBouncerCompatMode   bool          `mapstructure:"pgBouncerCompatMode"`
+	ApplicationName       string        `mapstructure:"applicationName"`
 
-	azureEnv azure.EnvironmentSettings
-	awsEnv   aws.EnvironmentSettings
+	azureEnv  azure.EnvironmentSettings
+	awsEnv    aws.EnvironmentSettings
+	eventSink events.Sink
 }
 
+// Event types emitted while establishing connections, for events.AuthEvent.Type.
+const (
+	AuthEventTokenAcquired     events.Type = "token_acquired"
+	AuthEventTokenRefreshError events.Type = "token_refresh_failed"
+)
+
+// SetEventSink sets an optional sink that receives auth events (token acquired, token
+// refresh failures) emitted while establishing connections. It's a no-op by default.
+func (m *PostgresAuthMetadata) SetEventSink(sink events.Sink) {
+	m.eventSink = sink
+}
+
+func (m *PostgresAuthMetadata) emit(event events.AuthEvent) {
+	if m.eventSink != nil {
+		m.eventSink.Emit(event)
+	}
+}
+
+// Pod-identity / workload-identity modes supported via the "podIdentity" metadata property.
+// These let the component obtain credentials from the platform instead of requiring
+// static keys or client secrets in component metadata.
+const (
+	PodIdentityAWSEKS        = "aws-eks"
+	PodIdentityAzureWorkload = "azure-workload"
+	PodIdentityAzureManaged  = "azure-managed"
+)
+
 // Reset the object.
 func (m *PostgresAuthMetadata) Reset() {
 	m.ConnectionString = ""
@@ -50,6 +86,10 @@ func (m *PostgresAuthMetadata) Reset() {
 	m.UseAzureAD = false
 	m.UseAWSIAM = false
 	m.QueryExecMode = ""
+	m.PodIdentity = ""
+	m.SessionInitSql = nil
+	m.PgBouncerCompatMode = false
+	m.ApplicationName = ""
 }
 
 type InitWithMetadataOpts struct {
@@ -66,6 +106,25 @@ func (m *PostgresAuthMetadata) InitWithMetadata(meta map[string]string, opts Ini
 		return errors.New("missing connection string")
 	}
 	switch {
+	case opts.AzureADEnabled && (m.PodIdentity == PodIdentityAzureWorkload || m.PodIdentity == PodIdentityAzureManaged):
+		// Pod identity takes care of its own token acquisition in GetPgxPoolConfig,
+		// but we still rely on UseAzureAD to select the Azure AD connection branch.
+		m.UseAzureAD = true
+		// The Azure environment supplies the token audience used in BeforeConnect.
+		m.azureEnv, err = azure.NewEnvironmentSettings(meta)
+		if err != nil {
+			return err
+		}
+	case opts.AWSIAMEnabled && m.PodIdentity == PodIdentityAWSEKS:
+		// IRSA/EKS Pod Identity is resolved through the default AWS credential chain,
+		// so we only need region information from metadata, not static keys.
+		m.UseAWSIAM = true
+		m.awsEnv, err = aws.NewEnvironmentSettings(meta)
+		if err != nil {
+			return err
+		}
+	case m.PodIdentity != "":
+		return fmt.Errorf("unsupported podIdentity value %q", m.PodIdentity)
 	case opts.AzureADEnabled && m.UseAzureAD:
 		// Populate the Azure environment if using Azure AD
 		m.azureEnv, err = azure.NewEnvironmentSettings(meta)
@@ -117,13 +176,34 @@ func (m *PostgresAuthMetadata) BuildAwsIamOptions(logger logger.Logger, properti
 	if sessionName == "" {
 		sessionName = "DaprDefaultSession"
 	}
+
+	// externalId and webIdentityTokenFile support cross-account role assumption and
+	// authenticating via a projected service-account token (e.g. EKS Pod Identity) instead
+	// of static credentials. assumeRoleChain lets operators chain through multiple roles,
+	// e.g. "pod-SA -> tenant-role -> db-role", where each entry is assumed in order using
+	// the credentials produced by the previous one.
+	externalID, _ := metadata.GetMetadataProperty(m.awsEnv.Metadata, "externalId")
+	webIdentityTokenFile, _ := metadata.GetMetadataProperty(m.awsEnv.Metadata, "webIdentityTokenFile")
+	assumeRoleChainStr, _ := metadata.GetMetadataProperty(m.awsEnv.Metadata, "assumeRoleChain")
+	var assumeRoleChain []string
+	if assumeRoleChainStr != "" {
+		assumeRoleChain = strings.Split(assumeRoleChainStr, ",")
+		for i := range assumeRoleChain {
+			assumeRoleChain[i] = strings.TrimSpace(assumeRoleChain[i])
+		}
+	}
+
 	return &aws.Options{
-		Region:        region,
-		AccessKey:     awsAccessKey,
-		SecretKey:     awsSecretKey,
-		SessionToken:  sessionToken,
-		AssumeRoleARN: assumeRoleArn,
-		SessionName:   sessionName,
+		Region:               region,
+		AccessKey:            awsAccessKey,
+		SecretKey:            awsSecretKey,
+		SessionToken:         sessionToken,
+		AssumeRoleARN:        assumeRoleArn,
+		SessionName:          sessionName,
+		PodIdentity:          m.PodIdentity,
+		ExternalID:           externalID,
+		WebIdentityTokenFile: webIdentityTokenFile,
+		AssumeRoleChain:      assumeRoleChain,
 
 		Logger:     logger,
 		Properties: properties,
@@ -159,7 +239,43 @@ func (m *PostgresAuthMetadata) GetPgxPoolConfig() (*pgxpool.Config, error) {
 		}
 	}
 
+	if m.ApplicationName != "" {
+		config.ConnConfig.RuntimeParams["application_name"] = m.ApplicationName
+	}
+
+	if m.PgBouncerCompatMode {
+		// PgBouncer in transaction pooling mode doesn't support prepared statements, since a
+		// session's connection can change between statements. The simple protocol avoids the
+		// Parse/Bind/Describe sequence entirely, and disabling the statement cache stops pgx
+		// from trying to reuse server-side statements across connections.
+		config.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+		config.ConnConfig.StatementCacheCapacity = 0
+		config.ConnConfig.DescriptionCacheCapacity = 0
+	}
+
+	if len(m.SessionInitSql) > 0 {
+		config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			for _, stmt := range m.SessionInitSql {
+				if _, err := conn.Exec(ctx, stmt); err != nil {
+					return fmt.Errorf("failed to execute sessionInitSql statement %q: %w", stmt, err)
+				}
+			}
+			return nil
+		}
+	}
+
 	switch {
+	case m.PodIdentity == PodIdentityAzureWorkload || m.PodIdentity == PodIdentityAzureManaged:
+		// Use Azure workload identity (federated service account token) or managed identity
+		// instead of requiring a client secret in metadata.
+		tokenCred, errCred := m.getPodIdentityAzureCredential()
+		if errCred != nil {
+			return nil, errCred
+		}
+
+		// Reset the password
+		config.ConnConfig.Password = ""
+		config.BeforeConnect = m.azureTokenBeforeConnect(tokenCred, "pod identity")
 	case m.UseAzureAD:
 		// Use Azure AD
 		tokenCred, errToken := m.azureEnv.GetTokenCredential()
@@ -169,24 +285,44 @@ func (m *PostgresAuthMetadata) GetPgxPoolConfig() (*pgxpool.Config, error) {
 
 		// Reset the password
 		config.ConnConfig.Password = ""
+		config.BeforeConnect = m.azureTokenBeforeConnect(tokenCred, "Azure AD")
+	}
 
-		// We need to retrieve the token every time we attempt a new connection
-		// This is because tokens expire, and connections can drop and need to be re-established at any time
-		// Fortunately, we can do this with the "BeforeConnect" hook
-		config.BeforeConnect = func(ctx context.Context, cc *pgx.ConnConfig) error {
-			at, errGetAccessToken := tokenCred.GetToken(ctx, policy.TokenRequestOptions{
-				Scopes: []string{
-					m.azureEnv.Cloud.Services[azure.ServiceOSSRDBMS].Audience + "/.default",
-				},
-			})
-			if errGetAccessToken != nil {
-				return errGetAccessToken
-			}
+	return config, nil
+}
 
-			cc.Password = at.Token
-			return nil
+// azureTokenBeforeConnect returns a pgxpool BeforeConnect hook that fetches a fresh Azure AD
+// access token from tokenCred and uses it as the connection password. We need to retrieve the
+// token every time we attempt a new connection, since tokens expire and connections can drop and
+// need to be re-established at any time; label identifies the credential source (pod identity vs
+// Azure AD) in emitted events.
+func (m *PostgresAuthMetadata) azureTokenBeforeConnect(tokenCred azcore.TokenCredential, label string) func(ctx context.Context, cc *pgx.ConnConfig) error {
+	return func(ctx context.Context, cc *pgx.ConnConfig) error {
+		at, errGetAccessToken := tokenCred.GetToken(ctx, policy.TokenRequestOptions{
+			Scopes: []string{
+				m.azureEnv.Cloud.Services[azure.ServiceOSSRDBMS].Audience + "/.default",
+			},
+		})
+		if errGetAccessToken != nil {
+			m.emit(events.AuthEvent{Type: AuthEventTokenRefreshError, Message: label + " token refresh failed", Err: errGetAccessToken})
+			return errGetAccessToken
 		}
+
+		m.emit(events.AuthEvent{Type: AuthEventTokenAcquired, Message: fmt.Sprintf("%s token acquired, expires at %s", label, at.ExpiresOn)})
+		cc.Password = at.Token
+		return nil
 	}
+}
 
-	return config, nil
+// getPodIdentityAzureCredential returns the azidentity credential for the configured
+// Azure pod-identity mode (workload identity for AKS, or managed identity elsewhere).
+func (m *PostgresAuthMetadata) getPodIdentityAzureCredential() (azcore.TokenCredential, error) {
+	switch m.PodIdentity {
+	case PodIdentityAzureWorkload:
+		return azidentity.NewWorkloadIdentityCredential(nil)
+	case PodIdentityAzureManaged:
+		return azidentity.NewManagedIdentityCredential(nil)
+	default:
+		return nil, fmt.Errorf("unsupported Azure podIdentity mode %q", m.PodIdentity)
+	}
 }
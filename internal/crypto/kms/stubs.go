@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"fmt"
+)
+
+// Azure Key Vault, GCP KMS and HashiCorp Vault are recognized providers but are not
+// yet wired up to their respective SDKs. They return a clear error rather than being
+// silently unavailable so callers can tell "not configured" apart from "not supported".
+
+func newAzureDecrypter(ctx context.Context, opts Options) (Decrypter, error) {
+	return nil, fmt.Errorf("kms: provider %q is not yet implemented", ProviderAzure)
+}
+
+func newGCPDecrypter(ctx context.Context, opts Options) (Decrypter, error) {
+	return nil, fmt.Errorf("kms: provider %q is not yet implemented", ProviderGCP)
+}
+
+func newHashiVaultDecrypter(ctx context.Context, opts Options) (Decrypter, error) {
+	return nil, fmt.Errorf("kms: provider %q is not yet implemented", ProviderHashiVault)
+}
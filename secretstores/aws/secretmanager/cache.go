@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"sync"
+	"time"
+)
+
+// secretCacheKey identifies a cached secret value. BulkGetSecret is the only caller, and it
+// always fetches the current value of a secret (it doesn't support pinning to a VersionId or
+// VersionStage), so the secret name is the only coordinate that needs to be part of the key.
+type secretCacheKey struct {
+	secretID string
+}
+
+type secretCacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// secretCache is a small in-memory TTL cache, keyed by secret name, that avoids re-fetching the
+// same secret repeatedly within a configurable window. A nil *secretCache or a non-positive ttl
+// disables caching entirely.
+type secretCache struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	items map[secretCacheKey]secretCacheEntry
+}
+
+func newSecretCache(ttl time.Duration) *secretCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &secretCache{ttl: ttl, items: map[secretCacheKey]secretCacheEntry{}}
+}
+
+func (c *secretCache) get(key secretCacheKey) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.items[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *secretCache) set(key secretCacheKey, value string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = secretCacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
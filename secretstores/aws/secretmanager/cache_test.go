@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSecretCacheGetSet(t *testing.T) {
+	c := newSecretCache(time.Hour)
+	key := secretCacheKey{secretID: "foo"}
+
+	if _, ok := c.get(key); ok {
+		t.Fatal("expected cache miss before set")
+	}
+
+	c.set(key, "bar")
+
+	value, ok := c.get(key)
+	if !ok || value != "bar" {
+		t.Fatalf("expected cache hit with value %q, got %q (ok=%v)", "bar", value, ok)
+	}
+}
+
+func TestSecretCacheExpiry(t *testing.T) {
+	c := newSecretCache(time.Hour)
+	key := secretCacheKey{secretID: "foo"}
+	c.set(key, "bar")
+
+	// Force the entry into the past instead of waiting out a real TTL.
+	c.mu.Lock()
+	entry := c.items[key]
+	entry.expiresAt = time.Now().Add(-time.Minute)
+	c.items[key] = entry
+	c.mu.Unlock()
+
+	if _, ok := c.get(key); ok {
+		t.Fatal("expected cache miss after expiry")
+	}
+}
+
+func TestNewSecretCacheDisabled(t *testing.T) {
+	if c := newSecretCache(0); c != nil {
+		t.Fatalf("expected nil cache for a non-positive ttl, got %+v", c)
+	}
+
+	var nilCache *secretCache
+	if _, ok := nilCache.get(secretCacheKey{secretID: "foo"}); ok {
+		t.Fatal("expected a nil cache to always miss")
+	}
+	nilCache.set(secretCacheKey{secretID: "foo"}, "bar") // must not panic
+}
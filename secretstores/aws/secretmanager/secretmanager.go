@@ -15,13 +15,23 @@ package secretmanager
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 
 	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
+	"github.com/dapr/components-contrib/common/authentication/events"
+	"github.com/dapr/components-contrib/internal/crypto/kms"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/kit/logger"
@@ -45,19 +55,74 @@ type SecretManagerMetaData struct {
 	SecretKey    string `json:"secretKey" mapstructure:"secretKey" mdignore:"true"`
 	SessionToken string `json:"sessionToken" mapstructure:"sessionToken" mdignore:"true"`
 	Endpoint     string `json:"endpoint" mapstructure:"endpoint"`
+	// PodIdentity selects an auth mode that resolves credentials from the platform
+	// instead of the accessKey/secretKey pair above. Only "aws-eks" (IRSA/EKS Pod
+	// Identity, resolved through the default AWS credential provider chain) is
+	// supported by this store.
+	PodIdentity string `json:"podIdentity" mapstructure:"podIdentity"`
+	// KMSKeyID, when set together with DecryptWithKMS, causes secret values to be
+	// treated as base64-encoded ciphertext blobs that are decrypted with this CMK
+	// before being returned to the caller.
+	KMSKeyID       string `json:"kmsKeyId" mapstructure:"kmsKeyId"`
+	DecryptWithKMS bool   `json:"decryptWithKms,string" mapstructure:"decryptWithKms"`
+	// CacheTTL is a duration string (e.g. "5m") that enables an in-memory cache for secrets
+	// fetched through BulkGetSecret, keyed by secret name only; entries are not distinguished
+	// by version. Empty or zero (the default) disables caching.
+	CacheTTL string `json:"cacheTtl" mapstructure:"cacheTtl"`
 }
 
+// PodIdentityAWSEKS is the only podIdentity mode supported by the AWS Secrets Manager store.
+const PodIdentityAWSEKS = "aws-eks"
+
+// Event types emitted from GetSecret and BulkGetSecret, for events.AuthEvent.Type.
+const (
+	EventVersionStageResolution events.Type = "version_stage_resolution"
+	EventThrottled              events.Type = "throttled"
+	EventCacheHit               events.Type = "cache_hit"
+	EventCacheMiss              events.Type = "cache_miss"
+)
+
 type smSecretStore struct {
 	authProvider awsAuth.Provider
 	logger       logger.Logger
+	eventSink    events.Sink
+
+	kmsDecrypter kms.Decrypter
+	kmsKeyID     string
+
+	cache *secretCache
+}
+
+// SetEventSink sets an optional sink that receives auth/cache events (cache hit/miss,
+// throttling, VersionStage resolution). It's a no-op by default.
+func (s *smSecretStore) SetEventSink(sink events.Sink) {
+	s.eventSink = sink
+}
+
+func (s *smSecretStore) emit(eventType events.Type, message string) {
+	if s.eventSink != nil {
+		s.eventSink.Emit(events.AuthEvent{Type: eventType, Message: message})
+	}
 }
 
+const (
+	// defaultBulkGetConcurrency bounds how many BatchGetSecretValue calls BulkGetSecret
+	// issues in parallel when metadata["concurrency"] isn't set. ListSecrets pages are
+	// fetched one after another.
+	defaultBulkGetConcurrency = 10
+	// bulkGetBatchSize is the maximum number of secrets BatchGetSecretValue accepts per call.
+	bulkGetBatchSize = 20
+)
+
 // Init creates an AWS secret manager client.
 func (s *smSecretStore) Init(ctx context.Context, metadata secretstores.Metadata) error {
 	meta, err := s.getSecretManagerMetadata(metadata)
 	if err != nil {
 		return err
 	}
+	if meta.PodIdentity != "" && meta.PodIdentity != PodIdentityAWSEKS {
+		return fmt.Errorf("podIdentity mode %q is not supported by the AWS Secrets Manager component", meta.PodIdentity)
+	}
 
 	opts := awsAuth.Options{
 		Logger:       s.logger,
@@ -66,6 +131,7 @@ func (s *smSecretStore) Init(ctx context.Context, metadata secretstores.Metadata
 		SecretKey:    meta.SecretKey,
 		SessionToken: meta.SessionToken,
 		Endpoint:     meta.Endpoint,
+		PodIdentity:  meta.PodIdentity,
 	}
 
 	provider, err := awsAuth.NewProvider(ctx, opts, awsAuth.GetConfig(opts))
@@ -73,9 +139,57 @@ func (s *smSecretStore) Init(ctx context.Context, metadata secretstores.Metadata
 		return err
 	}
 	s.authProvider = provider
+
+	if meta.DecryptWithKMS {
+		if meta.KMSKeyID == "" {
+			return errors.New("kmsKeyId is required when decryptWithKms is enabled")
+		}
+		s.kmsDecrypter, err = kms.NewDecrypter(ctx, kms.ProviderAWS, kms.Options{
+			Region:       meta.Region,
+			Endpoint:     meta.Endpoint,
+			AccessKey:    meta.AccessKey,
+			SecretKey:    meta.SecretKey,
+			SessionToken: meta.SessionToken,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create KMS decrypter: %w", err)
+		}
+		s.kmsKeyID = meta.KMSKeyID
+	}
+
+	var cacheTTL time.Duration
+	if meta.CacheTTL != "" {
+		cacheTTL, err = time.ParseDuration(meta.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid cacheTtl metadata value %q: %w", meta.CacheTTL, err)
+		}
+	}
+	s.cache = newSecretCache(cacheTTL)
+
 	return nil
 }
 
+// maybeDecryptWithKMS decrypts value through the configured KMS decrypter when one is set,
+// treating value as a base64-encoded ciphertext blob. When no decrypter is configured,
+// value is returned unchanged.
+func (s *smSecretStore) maybeDecryptWithKMS(ctx context.Context, value string) (string, error) {
+	if s.kmsDecrypter == nil {
+		return value, nil
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode secret as base64 ciphertext: %w", err)
+	}
+
+	plaintext, err := s.kmsDecrypter.Decrypt(ctx, s.kmsKeyID, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("couldn't decrypt secret with KMS: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values.
 func (s *smSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
 	var versionID *string
@@ -85,6 +199,7 @@ func (s *smSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecre
 	var versionStage *string
 	if value, ok := req.Metadata[VersionStage]; ok {
 		versionStage = &value
+		s.emit(EventVersionStageResolution, fmt.Sprintf("resolving secret %q at version stage %q", req.Name, value))
 	}
 	output, err := s.authProvider.SecretManager().Manager.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
 		SecretId:     &req.Name,
@@ -92,6 +207,9 @@ func (s *smSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecre
 		VersionStage: versionStage,
 	})
 	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ThrottlingException" {
+			s.emit(EventThrottled, fmt.Sprintf("AWS Secrets Manager throttled GetSecretValue for %q", req.Name))
+		}
 		return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't get secret: %s", err)
 	}
 
@@ -99,46 +217,169 @@ func (s *smSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecre
 		Data: map[string]string{},
 	}
 	if output.Name != nil && output.SecretString != nil {
-		resp.Data[*output.Name] = *output.SecretString
+		value, err := s.maybeDecryptWithKMS(ctx, *output.SecretString)
+		if err != nil {
+			return secretstores.GetSecretResponse{Data: nil}, err
+		}
+		resp.Data[*output.Name] = value
 	}
 
 	return resp, nil
 }
 
+// parseBulkGetFilters parses the "filter" request metadata value into ListSecrets filters. A
+// bare value (no "=") is treated as a name filter. Otherwise, v is split on "," or ";" into one
+// or more "key=value" pairs, each becoming its own *secretsmanager.Filter; ListSecrets ANDs
+// filters together, so this is what lets a "tag-key"/"tag-value" pair be matched jointly.
+func parseBulkGetFilters(v string) ([]*secretsmanager.Filter, error) {
+	if !strings.Contains(v, "=") {
+		return []*secretsmanager.Filter{
+			{Key: aws.String("name"), Values: []*string{aws.String(v)}},
+		}, nil
+	}
+
+	pairs := strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ';' })
+	filters := make([]*secretsmanager.Filter, 0, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid filter metadata pair %q: expected key=value", pair)
+		}
+		filters = append(filters, &secretsmanager.Filter{
+			Key:    aws.String(strings.TrimSpace(key)),
+			Values: []*string{aws.String(strings.TrimSpace(value))},
+		})
+	}
+	return filters, nil
+}
+
 // BulkGetSecret retrieves all secrets in the store and returns a map of decrypted string/string values.
+//
+// It accepts three optional request metadata properties: "maxResults" (page size passed to
+// ListSecrets), "filter" (a name or tag filter passed through to ListSecrets, see below) and
+// "concurrency" (how many BatchGetSecretValue calls run in parallel, default
+// defaultBulkGetConcurrency).
+//
+// "filter" is either a bare value, matched against secret names (equivalent to "name=value"),
+// or one or more "key=value" pairs, separated by "," or ";", where key is one of the ListSecrets
+// filter keys: "name", "tag-key", "tag-value", "description" or "all". ListSecrets ANDs filters
+// together, so matching a specific tag requires both the "tag-key" and "tag-value" pairs, e.g.
+// "tag-key=team;tag-value=payments".
+// Secrets are fetched through BatchGetSecretValue in batches of bulkGetBatchSize, fanned out
+// across a bounded worker pool; a failure fetching one batch is logged and skipped rather than
+// aborting the whole request.
 func (s *smSecretStore) BulkGetSecret(ctx context.Context, req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
 	resp := secretstores.BulkGetSecretResponse{
 		Data: map[string]map[string]string{},
 	}
 
-	search := true
-	var nextToken *string = nil
+	listInput := &secretsmanager.ListSecretsInput{}
+	if v, ok := req.Metadata["maxResults"]; ok && v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return secretstores.BulkGetSecretResponse{Data: nil}, fmt.Errorf("invalid maxResults metadata value %q: %w", v, err)
+		}
+		listInput.MaxResults = &n
+	}
+	if v, ok := req.Metadata["filter"]; ok && v != "" {
+		filters, err := parseBulkGetFilters(v)
+		if err != nil {
+			return secretstores.BulkGetSecretResponse{Data: nil}, err
+		}
+		listInput.Filters = filters
+	}
 
-	for search {
-		output, err := s.authProvider.SecretManager().Manager.ListSecretsWithContext(ctx, &secretsmanager.ListSecretsInput{
-			MaxResults: nil,
-			NextToken:  nextToken,
-		})
+	concurrency := defaultBulkGetConcurrency
+	if v, ok := req.Metadata["concurrency"]; ok && v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return secretstores.BulkGetSecretResponse{Data: nil}, fmt.Errorf("invalid concurrency metadata value %q", v)
+		}
+		concurrency = n
+	}
+
+	var names []*string
+	for nextToken := (*string)(nil); ; {
+		listInput.NextToken = nextToken
+		output, err := s.authProvider.SecretManager().Manager.ListSecretsWithContext(ctx, listInput)
 		if err != nil {
-			return secretstores.BulkGetSecretResponse{Data: nil}, fmt.Errorf("couldn't list secrets: %s", err)
+			return secretstores.BulkGetSecretResponse{Data: nil}, fmt.Errorf("couldn't list secrets: %w", err)
 		}
 
 		for _, entry := range output.SecretList {
-			secrets, err := s.authProvider.SecretManager().Manager.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
-				SecretId: entry.Name,
+			if entry.Name != nil {
+				names = append(names, entry.Name)
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	var toFetch []*string
+	var mu sync.Mutex
+	for _, name := range names {
+		if value, ok := s.cache.get(secretCacheKey{secretID: *name}); ok {
+			s.emit(EventCacheHit, fmt.Sprintf("serving secret %q from cache", *name))
+			resp.Data[*name] = map[string]string{*name: value}
+			continue
+		}
+		s.emit(EventCacheMiss, fmt.Sprintf("secret %q not cached, fetching", *name))
+		toFetch = append(toFetch, name)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < len(toFetch); i += bulkGetBatchSize {
+		end := i + bulkGetBatchSize
+		if end > len(toFetch) {
+			end = len(toFetch)
+		}
+		batch := toFetch[i:end]
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(batch []*string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			output, err := s.authProvider.SecretManager().Manager.BatchGetSecretValueWithContext(ctx, &secretsmanager.BatchGetSecretValueInput{
+				SecretIdList: batch,
 			})
 			if err != nil {
-				return secretstores.BulkGetSecretResponse{Data: nil}, fmt.Errorf("couldn't get secret: %s", *entry.Name)
+				if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ThrottlingException" {
+					s.emit(EventThrottled, fmt.Sprintf("AWS Secrets Manager throttled BatchGetSecretValue for %d secrets", len(batch)))
+				}
+				s.logger.Warnf("secretmanager: batch get failed for %d secrets, skipping: %v", len(batch), err)
+				return
 			}
 
-			if entry.Name != nil && secrets.SecretString != nil {
-				resp.Data[*entry.Name] = map[string]string{*entry.Name: *secrets.SecretString}
+			for _, entry := range output.Errors {
+				s.logger.Warnf("secretmanager: couldn't get secret %q: %s", aws.StringValue(entry.SecretId), aws.StringValue(entry.Message))
 			}
-		}
 
-		nextToken = output.NextToken
-		search = output.NextToken != nil
+			for _, secret := range output.SecretValues {
+				if secret.Name == nil || secret.SecretString == nil {
+					continue
+				}
+
+				value, err := s.maybeDecryptWithKMS(ctx, *secret.SecretString)
+				if err != nil {
+					s.logger.Warnf("secretmanager: couldn't decrypt secret %q, skipping: %v", *secret.Name, err)
+					continue
+				}
+
+				s.cache.set(secretCacheKey{secretID: *secret.Name}, value)
+
+				mu.Lock()
+				resp.Data[*secret.Name] = map[string]string{*secret.Name: value}
+				mu.Unlock()
+			}
+		}(batch)
 	}
+	wg.Wait()
 
 	return resp, nil
 }
@@ -170,6 +411,11 @@ func (s *smSecretStore) GetComponentMetadata() (metadataInfo metadata.MetadataMa
 }
 
 func (s *smSecretStore) Close() error {
+	if s.kmsDecrypter != nil {
+		if err := s.kmsDecrypter.Close(); err != nil {
+			return err
+		}
+	}
 	if s.authProvider != nil {
 		return s.authProvider.Close()
 	}